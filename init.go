@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Global flag variable for the init subcommand.
+var initMonths int
+
+// sampleProducts lists the competing products available throughout the
+// generated sample timeline, each with the base rate that per-month noise
+// is added to and its monthly cost.
+var sampleProducts = []struct {
+	Name        string
+	AnnualRate  float64
+	MonthlyCost float64
+	Tiers       []RateTier
+	IntroRate   float64
+	IntroMonths int
+}{
+	{"SteadySaver", 3.5, 0, nil, 0, 0},
+	{
+		Name:        "BoostBank",
+		AnnualRate:  4.2,
+		MonthlyCost: 2.5,
+		Tiers: []RateTier{
+			{UpToBalance: decimal.NewFromInt(5000), AnnualRate: decimal.NewFromFloat(3.2)},
+			{UpToBalance: decimal.Zero, AnnualRate: decimal.NewFromFloat(4.2)},
+		},
+		IntroRate:   6,
+		IntroMonths: 3,
+	},
+	{"FlexFund", 3.9, 1, nil, 0, 0},
+}
+
+func newInitCmd() *cobra.Command {
+	initCmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write example interest_data.json and products_data.json files",
+		Run: func(cmd *cobra.Command, args []string) {
+			if initMonths < 2 {
+				log.Fatalf("--months must be at least 2")
+			}
+
+			monthlyData, productsData := generateSampleData(initMonths)
+
+			if err := writeJSONFile(jsonFileName, monthlyData); err != nil {
+				log.Fatalf("Error writing %s: %v", jsonFileName, err)
+			}
+			if err := writeJSONFile(productsFileName, productsData); err != nil {
+				log.Fatalf("Error writing %s: %v", productsFileName, err)
+			}
+
+			fmt.Printf("Wrote %s and %s with %d months of sample history.\n", jsonFileName, productsFileName, initMonths)
+		},
+	}
+
+	initCmd.Flags().IntVar(&initMonths, "months", 24, "number of months of synthetic history to generate")
+
+	return initCmd
+}
+
+func writeJSONFile(filename string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o644)
+}
+
+// generateSampleData builds `months` months of MonthlyData plus matching
+// MonthlyProducts, with two extra trailing months of products-only data so
+// printFutureProductComparisons has something to show. The user switches
+// from the first to the second sample product at the timeline's midpoint,
+// and each product's rate gets a little noise every month so the history
+// isn't perfectly flat. The second product (BoostBank) carries a tier
+// schedule and a promotional intro rate, so switching to it also exercises
+// tieredInterest and effectiveInterest in the generated history.
+func generateSampleData(months int) ([]MonthlyData, []MonthlyProducts) {
+	rng := rand.New(rand.NewSource(42))
+
+	start := time.Now().AddDate(0, -months, 0)
+	deposit := decimal.NewFromInt(10000)
+	capital := decimal.Zero
+	switchMonth := months / 2
+
+	var monthlyData []MonthlyData
+	var productsData []MonthlyProducts
+
+	currentProduct := sampleProducts[0].Name
+	monthsOnCurrentProduct := 0
+
+	const futureMonths = 2
+	for i := 0; i < months+futureMonths; i++ {
+		date := start.AddDate(0, i, 0)
+		year, month := date.Year(), date.Month().String()
+
+		if i == switchMonth {
+			currentProduct = sampleProducts[1].Name
+			monthsOnCurrentProduct = 0
+		}
+
+		var products []Product
+		for _, sp := range sampleProducts {
+			noise := (rng.Float64() - 0.5) * 0.4 // +/- 0.2 percentage points of rate noise
+			rate := decimal.NewFromFloat(sp.AnnualRate + noise).Round(2)
+			products = append(products, Product{
+				Name:        sp.Name,
+				AnnualRate:  rate,
+				MonthlyCost: decimal.NewFromFloat(sp.MonthlyCost),
+				Tiers:       sp.Tiers,
+				IntroRate:   decimal.NewFromFloat(sp.IntroRate),
+				IntroMonths: sp.IntroMonths,
+			})
+		}
+		productsData = append(productsData, MonthlyProducts{Year: year, Month: month, Products: products})
+
+		if i >= months {
+			continue // trailing months only get a products listing, no history yet
+		}
+
+		capital = capital.Add(deposit)
+		monthsOnCurrentProduct++
+
+		var current Product
+		for _, p := range products {
+			if p.Name == currentProduct {
+				current = p
+				break
+			}
+		}
+		interest := effectiveInterest(current, capital, monthsOnCurrentProduct)
+		capital = capital.Add(interest)
+
+		monthlyData = append(monthlyData, MonthlyData{
+			Year:               year,
+			Month:              month,
+			ActualInterest:     interest,
+			CurrentProductName: currentProduct,
+		})
+	}
+
+	return monthlyData, productsData
+}
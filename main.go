@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -12,28 +11,39 @@ import (
 	"time"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 )
 
-// Product represents a financial product (card)
+// Product represents a financial product (card). Tiers, IntroRate and
+// IntroMonths are optional: a product with no Tiers uses the flat
+// AnnualRate, and a zero IntroMonths means there is no promotional period.
 type Product struct {
-	Name        string  `json:"name"`
-	AnnualRate  float64 `json:"annualRate"`
-	MonthlyCost float64 `json:"monthlyCost"`
+	Name        string     `json:"name"`
+	AnnualRate  Money      `json:"annualRate"`
+	MonthlyCost Money      `json:"monthlyCost"`
+	Tiers       []RateTier `json:"tiers,omitempty"`
+	IntroRate   Money      `json:"introRate"`
+	IntroMonths int        `json:"introMonths,omitempty"`
 }
 
 // MonthlyData represents the data for each month
 type MonthlyData struct {
-	Year               int     `json:"year"`
-	Month              string  `json:"month"`
-	ActualInterest     float64 `json:"actualInterest"`
-	CurrentProductName string  `json:"currentProductName"`
+	Year               int    `json:"year"`
+	Month              string `json:"month"`
+	ActualInterest     Money  `json:"actualInterest"`
+	CurrentProductName string `json:"currentProductName"`
 }
 
-// MonthlyProducts represents the products available in a given month
+// MonthlyProducts represents the products available as of a given date. Day
+// is optional and defaults to the 1st, but a rate change that took effect
+// mid-month can be recorded by adding another MonthlyProducts entry for the
+// same Year/Month with a later Day; RateHistory resolves the rate in effect
+// on any date from whatever entries exist.
 type MonthlyProducts struct {
 	Year     int       `json:"year"`
 	Month    string    `json:"month"`
+	Day      int       `json:"day,omitempty"`
 	Products []Product `json:"products"`
 }
 
@@ -41,7 +51,7 @@ type MonthlyProducts struct {
 var (
 	jsonFileName     string
 	productsFileName string
-	csvOutput        bool
+	outputFormat     string
 )
 
 func main() {
@@ -49,6 +59,10 @@ func main() {
 		Use:   "interest",
 		Short: "A tool to compare financial products and interests.",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !validOutputFormats[outputFormat] {
+				log.Fatalf("invalid --format %q (want table|csv|json|md|html)", outputFormat)
+			}
+
 			monthlyData, err := loadDataFromJSON(jsonFileName)
 			if err != nil {
 				log.Fatalf("Error loading JSON data: %v", err)
@@ -59,16 +73,22 @@ func main() {
 				log.Fatalf("Error loading products data: %v", err)
 			}
 
-			printInterestComparisonTable(monthlyData, productsData, csvOutput)
-			printProductComparisonTable(monthlyData, productsData, csvOutput)
-			printFutureProductComparisons(monthlyData, productsData, csvOutput)
+			session := newRenderSession(outputFormat)
+			printInterestComparisonTable(monthlyData, productsData, session)
+			printProductComparisonTable(monthlyData, productsData, session)
+			printFutureProductComparisons(monthlyData, productsData, session)
+			session.Finish()
 		},
 	}
 
 	// Define flags using Cobra
 	rootCmd.PersistentFlags().StringVar(&jsonFileName, "jsondata", "interest_data.json", "path to JSON data file")
 	rootCmd.PersistentFlags().StringVar(&productsFileName, "productsdata", "products_data.json", "path to products JSON file")
-	rootCmd.PersistentFlags().BoolVar(&csvOutput, "csv", false, "output tables in CSV format")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "table", "output format: table|csv|json|md|html")
+
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newProjectCmd())
+	rootCmd.AddCommand(newInitCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
@@ -103,7 +123,7 @@ func formatHeader(header string) string {
 	return strings.ReplaceAll(header, " ", "\n")
 }
 
-func printInterestComparisonTable(monthlyData []MonthlyData, productsData []MonthlyProducts, csvOutput bool) {
+func printInterestComparisonTable(monthlyData []MonthlyData, productsData []MonthlyProducts, session *renderSession) {
 	headers := []string{
 		"Year",
 		"Month",
@@ -118,28 +138,10 @@ func printInterestComparisonTable(monthlyData []MonthlyData, productsData []Mont
 		"Estimated Deposit",
 	}
 
-	var table *tablewriter.Table
-	var writer *csv.Writer
-
-	if csvOutput {
-		writer = csv.NewWriter(os.Stdout)
-		defer writer.Flush()
-
-		// Write headers
-		if err := writer.Write(headers); err != nil {
-			log.Fatalln("error writing CSV headers:", err)
-		}
-	} else {
-		table = tablewriter.NewWriter(os.Stdout)
-		// Format headers with newlines for compactness
-		formattedHeaders := make([]string, len(headers))
-		for i, header := range headers {
-			formattedHeaders[i] = formatHeader(header)
-		}
-		table.SetHeader(formattedHeaders)
-
-		// Set column alignment
-		table.SetColumnAlignment([]int{
+	renderer := session.Section("interestComparison", "Interest Comparison Table:")
+	renderer.WriteHeader(headers)
+	if a, ok := renderer.(aligner); ok {
+		a.SetAlignment([]int{
 			tablewriter.ALIGN_DEFAULT, // Year
 			tablewriter.ALIGN_DEFAULT, // Month
 			tablewriter.ALIGN_RIGHT,   // Plan Rate
@@ -153,6 +155,7 @@ func printInterestComparisonTable(monthlyData []MonthlyData, productsData []Mont
 			tablewriter.ALIGN_RIGHT,   // Estimated Deposit
 		})
 	}
+	defer renderer.Flush()
 
 	// Create a map from year-month to products for quick lookup
 	productsMap := make(map[string][]Product)
@@ -161,7 +164,11 @@ func printInterestComparisonTable(monthlyData []MonthlyData, productsData []Mont
 		productsMap[key] = mp.Products
 	}
 
-	var previousCapital float64
+	// Rate histories let us resolve the rate in effect for a product on its
+	// exact date, instead of forcing exactly one flat rate per month.
+	rateHistories := buildRateHistories(productsData)
+
+	var previousCapital Money
 	for idx, data := range monthlyData {
 		key := fmt.Sprintf("%d-%s", data.Year, data.Month)
 		products, ok := productsMap[key]
@@ -170,45 +177,54 @@ func printInterestComparisonTable(monthlyData []MonthlyData, productsData []Mont
 			continue
 		}
 
+		date, err := time.Parse("2006-January", key)
+		if err != nil {
+			fmt.Printf("Error parsing date '%s': %v\n", key, err)
+			continue
+		}
+
 		currentProductName := data.CurrentProductName
-		var currentRate float64
-		var currentMonthlyCost float64
+		var currentRate Money
+		var currentMonthlyCost Money
+		var currentProduct Product
+		if history, ok := rateHistories[currentProductName]; ok {
+			if rate, found := history.DescendFirstLessOrEqual(date); found {
+				currentRate = rate
+			}
+		}
 		for _, product := range products {
 			if product.Name == currentProductName {
-				currentRate = product.AnnualRate
 				currentMonthlyCost = product.MonthlyCost
+				currentProduct = product
 				break
 			}
 		}
 
-		if currentRate == 0 {
+		if currentRate.IsZero() {
 			fmt.Printf("Current product not found for %s %d\n", data.Month, data.Year)
 			continue
 		}
 
 		// Compute Interest After Plan Cost
-		interestAfterPlanCost := data.ActualInterest - currentMonthlyCost
+		interestAfterPlanCost := data.ActualInterest.Sub(currentMonthlyCost)
 
-		// Compute Capital
-		capital := data.ActualInterest / (currentRate / 100 / 12)
+		// Compute Capital by inverting the rate that was actually in effect
+		// (tiers and any active intro rate), not the flat product rate.
+		capital := inverseEffectiveInterest(currentProduct, data.ActualInterest, monthsOnProduct(monthlyData, idx))
 
-		var estimatedInterest, interestDifference, diffCapital, estimatedDeposit float64
+		var estimatedInterest, interestDifference, diffCapital, estimatedDeposit Money
 		if idx > 0 {
-			// Calculate estimated interest based on previous capital
-			estimatedInterest = previousCapital * (currentRate / 100 / 12)
+			// Calculate estimated interest based on previous capital, walking
+			// the plan's tiers and honoring any active intro rate
+			estimatedInterest = effectiveInterest(currentProduct, previousCapital, monthsOnProduct(monthlyData, idx))
 			// Calculate difference between actual interest and estimated interest
-			interestDifference = data.ActualInterest - estimatedInterest
+			interestDifference = data.ActualInterest.Sub(estimatedInterest)
 			// Difference in capital
-			diffCapital = capital - previousCapital
+			diffCapital = capital.Sub(previousCapital)
 			// Estimated deposit (difference in capital minus actual interest)
-			estimatedDeposit = diffCapital - data.ActualInterest
-		} else {
-			// For the first month, no previous capital
-			estimatedInterest = 0
-			interestDifference = 0
-			diffCapital = 0
-			estimatedDeposit = 0
+			estimatedDeposit = diffCapital.Sub(data.ActualInterest)
 		}
+		// For the first month, no previous capital: all four stay zero-valued.
 
 		// Update previousCapital for next iteration
 		previousCapital = capital
@@ -216,64 +232,37 @@ func printInterestComparisonTable(monthlyData []MonthlyData, productsData []Mont
 		row := []string{
 			fmt.Sprintf("%d", data.Year),
 			data.Month,
-			fmt.Sprintf("%.2f%%", currentRate),
-			fmt.Sprintf("%.2f", currentMonthlyCost),
-			fmt.Sprintf("%.2f", data.ActualInterest),
-			fmt.Sprintf("%.2f", interestAfterPlanCost),
-			fmt.Sprintf("%.2f", estimatedInterest),
-			fmt.Sprintf("%.2f", interestDifference),
-			fmt.Sprintf("%.2f", capital),
-			fmt.Sprintf("%.2f", diffCapital),
-			fmt.Sprintf("%.2f", estimatedDeposit),
+			currentRate.StringFixed(2) + "%",
+			currentMonthlyCost.StringFixed(2),
+			data.ActualInterest.StringFixed(2),
+			interestAfterPlanCost.StringFixed(2),
+			estimatedInterest.StringFixed(2),
+			interestDifference.StringFixed(2),
+			capital.StringFixed(2),
+			diffCapital.StringFixed(2),
+			estimatedDeposit.StringFixed(2),
 		}
 
-		if csvOutput {
-			if err := writer.Write(row); err != nil {
-				log.Fatalln("error writing CSV record:", err)
+		// Style columns 2 onwards; Year and Month stay unstyled.
+		styles := make([]CellStyle, len(row))
+		for i := 2; i < len(row); i++ {
+			valueStr := strings.TrimSuffix(row[i], "%")
+			value, err := strconv.ParseFloat(valueStr, 64)
+			if err != nil {
+				continue
 			}
-		} else {
-			// Create colors slice
-			colors := make([]tablewriter.Colors, len(row))
-			// For columns 2 onwards (indexes 2 to len(row)-1)
-			for i := 2; i < len(row); i++ {
-				// Parse the value
-				valueStr := row[i]
-				// Remove any percentage sign
-				valueStr = strings.TrimSuffix(valueStr, "%")
-				// Parse the value
-				value, err := strconv.ParseFloat(valueStr, 64)
-				if err != nil {
-					// If parsing fails, no color
-					colors[i] = tablewriter.Colors{}
-					continue
-				}
-				if value < 0 {
-					// Negative value, color red
-					colors[i] = tablewriter.Colors{tablewriter.FgRedColor}
-				} else if value > 0 && value < 1 {
-					// Positive but less than 1, color yellow
-					colors[i] = tablewriter.Colors{tablewriter.FgYellowColor}
-				} else {
-					// No color
-					colors[i] = tablewriter.Colors{}
-				}
+			if value < 0 {
+				styles[i] = StyleBad
+			} else if value > 0 && value < 1 {
+				styles[i] = StyleWarn
 			}
-			// For Year and Month columns, no color
-			colors[0] = tablewriter.Colors{}
-			colors[1] = tablewriter.Colors{}
-
-			// Add the row with colors
-			table.Rich(row, colors)
 		}
-	}
 
-	if !csvOutput {
-		fmt.Println("Interest Comparison Table:")
-		table.Render()
+		renderer.WriteRow(row, styles)
 	}
 }
 
-func printProductComparisonTable(monthlyData []MonthlyData, productsData []MonthlyProducts, csvOutput bool) {
+func printProductComparisonTable(monthlyData []MonthlyData, productsData []MonthlyProducts, session *renderSession) {
 	// Get the last month's data
 	lastMonthData := monthlyData[len(monthlyData)-1]
 	year := lastMonthData.Year
@@ -295,46 +284,36 @@ func printProductComparisonTable(monthlyData []MonthlyData, productsData []Month
 
 	// Use the current product name from the last month's data
 	currentProductName := lastMonthData.CurrentProductName
-	var currentRate float64
+	var currentRate Money
+	var currentProduct Product
 	for _, product := range products {
 		if product.Name == currentProductName {
 			currentRate = product.AnnualRate
+			currentProduct = product
 			break
 		}
 	}
 
-	if currentRate == 0 {
+	if currentRate.IsZero() {
 		fmt.Printf("Current product not found in the last month (%s %d)\n", month, year)
 		return
 	}
 
-	// Compute the capital for the last month
-	capital := lastMonthData.ActualInterest / (currentRate / 100 / 12)
-
-	headers := []string{"Product", "Annual Rate", "Monthly Cost", "Projected Interest", "Net Gain"}
-
-	var table *tablewriter.Table
-	var writer *csv.Writer
+	// The current plan has been held for monthsOnCurrent months, so its intro
+	// rate (if any) may already have expired; a plan the user hasn't joined
+	// yet is evaluated as if they switched onto it this month.
+	monthsOnCurrent := monthsOnProduct(monthlyData, len(monthlyData)-1)
 
-	if csvOutput {
-		writer = csv.NewWriter(os.Stdout)
-		defer writer.Flush()
+	// Compute the capital for the last month by inverting the rate that was
+	// actually in effect (tiers and any active intro rate), not the flat rate.
+	capital := inverseEffectiveInterest(currentProduct, lastMonthData.ActualInterest, monthsOnCurrent)
 
-		// Write headers
-		if err := writer.Write(headers); err != nil {
-			log.Fatalln("error writing CSV headers:", err)
-		}
-	} else {
-		table = tablewriter.NewWriter(os.Stdout)
-		// Format headers with newlines for compactness
-		formattedHeaders := make([]string, len(headers))
-		for i, header := range headers {
-			formattedHeaders[i] = formatHeader(header)
-		}
-		table.SetHeader(formattedHeaders)
+	headers := []string{"Product", "Annual Rate", "Monthly Cost", "Projected Interest", "Net Gain"}
 
-		// Set column alignment
-		table.SetColumnAlignment([]int{
+	renderer := session.Section("productComparison", fmt.Sprintf("Product Comparison Table for %s %d:", month, year))
+	renderer.WriteHeader(headers)
+	if a, ok := renderer.(aligner); ok {
+		a.SetAlignment([]int{
 			tablewriter.ALIGN_DEFAULT, // Product
 			tablewriter.ALIGN_RIGHT,   // Annual Rate
 			tablewriter.ALIGN_RIGHT,   // Monthly Cost
@@ -342,27 +321,32 @@ func printProductComparisonTable(monthlyData []MonthlyData, productsData []Month
 			tablewriter.ALIGN_RIGHT,   // Net Gain
 		})
 	}
+	defer renderer.Flush()
 
 	type ProductRow struct {
 		Row         []string
-		NetGain     float64
+		NetGain     Money
 		ProductName string
 	}
 
 	var productRows []ProductRow
-	var maxNetGain float64 = -1e9 // Initialize to a very small number
+	maxNetGain := decimal.NewFromInt(-1000000000) // Initialize to a very small number
 
 	// First pass: compute net gains and find the maximum net gain
 	for _, product := range products {
-		projectedInterest := capital * (product.AnnualRate / 100 / 12)
-		netGain := projectedInterest - product.MonthlyCost
+		months := 1
+		if product.Name == currentProductName {
+			months = monthsOnCurrent
+		}
+		projectedInterest := effectiveInterest(product, capital, months)
+		netGain := projectedInterest.Sub(product.MonthlyCost)
 
 		row := []string{
 			product.Name,
-			fmt.Sprintf("%.2f%%", product.AnnualRate),
-			fmt.Sprintf("%.2f", product.MonthlyCost),
-			fmt.Sprintf("%.2f", projectedInterest),
-			fmt.Sprintf("%.2f", netGain),
+			product.AnnualRate.StringFixed(2) + "%",
+			product.MonthlyCost.StringFixed(2),
+			projectedInterest.StringFixed(2),
+			netGain.StringFixed(2),
 		}
 
 		productRows = append(productRows, ProductRow{
@@ -372,51 +356,35 @@ func printProductComparisonTable(monthlyData []MonthlyData, productsData []Month
 		})
 
 		// Update maxNetGain
-		if netGain > maxNetGain {
+		if netGain.GreaterThan(maxNetGain) {
 			maxNetGain = netGain
 		}
 	}
 
-	// Second pass: output the rows with appropriate colors
+	// Second pass: output the rows with appropriate styling
 	for _, pr := range productRows {
 		row := pr.Row
-		if csvOutput {
-			if err := writer.Write(row); err != nil {
-				log.Fatalln("error writing CSV record:", err)
-			}
-		} else {
-			// Create colors slice
-			colors := make([]tablewriter.Colors, len(row))
-			// Color the Net Gain column (index 4)
-			if pr.NetGain == maxNetGain {
-				// Highest net gain, color green
-				colors[4] = tablewriter.Colors{tablewriter.FgGreenColor}
-			} else if pr.NetGain < 0 {
-				// Negative net gain, color red
-				colors[4] = tablewriter.Colors{tablewriter.FgRedColor}
-			} else {
-				colors[4] = tablewriter.Colors{}
-			}
+		styles := make([]CellStyle, len(row))
 
-			// Color the entire row yellow if this is the current plan
-			if pr.ProductName == currentProductName {
-				// Set foreground color yellow for all cells in the row
-				for i := 0; i < len(row); i++ {
-					colors[i] = tablewriter.Colors{tablewriter.FgHiYellowColor}
-				}
-			}
+		// Style the Net Gain column (index 4)
+		if pr.NetGain.Equal(maxNetGain) {
+			styles[4] = StyleGood
+		} else if pr.NetGain.IsNegative() {
+			styles[4] = StyleBad
+		}
 
-			table.Rich(row, colors)
+		// Highlight the entire row if this is the current plan
+		if pr.ProductName == currentProductName {
+			for i := range styles {
+				styles[i] = StyleHighlight
+			}
 		}
-	}
 
-	if !csvOutput {
-		fmt.Printf("\nProduct Comparison Table for %s %d:\n", month, year)
-		table.Render()
+		renderer.WriteRow(row, styles)
 	}
 }
 
-func printFutureProductComparisons(monthlyData []MonthlyData, productsData []MonthlyProducts, csvOutput bool) {
+func printFutureProductComparisons(monthlyData []MonthlyData, productsData []MonthlyProducts, session *renderSession) {
 	// Get the last date from the monthly data
 	lastData := monthlyData[len(monthlyData)-1]
 	lastDateStr := fmt.Sprintf("%d-%s", lastData.Year, lastData.Month)
@@ -426,27 +394,21 @@ func printFutureProductComparisons(monthlyData []MonthlyData, productsData []Mon
 		return
 	}
 
-	// Compute the capital from the last month
-	// Get the current rate from the last month's product
-	var currentRate float64
-	for _, mp := range productsData {
-		if mp.Year == lastData.Year && mp.Month == lastData.Month {
-			for _, product := range mp.Products {
-				if product.Name == lastData.CurrentProductName {
-					currentRate = product.AnnualRate
-					break
-				}
-			}
-			break
-		}
-	}
-
-	if currentRate == 0 {
+	// Get the last month's product definition, resolved as of its exact date
+	// so mid-month rate changes are honored.
+	currentProduct, ok := resolveProductAsOf(productsData, lastData.CurrentProductName, lastDate)
+	if !ok || currentProduct.AnnualRate.IsZero() {
 		fmt.Printf("Current rate not found for last month (%s %d)\n", lastData.Month, lastData.Year)
 		return
 	}
 
-	capital := lastData.ActualInterest / (currentRate / 100 / 12)
+	// The current plan's intro rate, if any, is measured from when the user
+	// actually switched onto it, carried forward across the future months.
+	monthsOnCurrent := monthsOnProduct(monthlyData, len(monthlyData)-1)
+
+	// Compute the capital from the last month by inverting the rate that was
+	// actually in effect (tiers and any active intro rate), not the flat rate.
+	capital := inverseEffectiveInterest(currentProduct, lastData.ActualInterest, monthsOnCurrent)
 
 	// Collect future months' products
 	type FutureProduct struct {
@@ -485,33 +447,16 @@ func printFutureProductComparisons(monthlyData []MonthlyData, productsData []Mon
 	currentProductName := lastData.CurrentProductName
 
 	// For each future month, print the product comparison table
-	for _, fp := range futureProducts {
+	for futureIdx, fp := range futureProducts {
 		year, month := fp.Date.Year(), fp.Date.Format("January")
 
 		headers := []string{"Product", "Annual Rate", "Monthly Cost", "Projected Interest", "Net Gain"}
 
-		var table *tablewriter.Table
-		var writer *csv.Writer
-
-		if csvOutput {
-			writer = csv.NewWriter(os.Stdout)
-			defer writer.Flush()
-
-			// Write headers
-			if err := writer.Write(headers); err != nil {
-				log.Fatalln("error writing CSV headers:", err)
-			}
-		} else {
-			table = tablewriter.NewWriter(os.Stdout)
-			// Format headers with newlines for compactness
-			formattedHeaders := make([]string, len(headers))
-			for i, header := range headers {
-				formattedHeaders[i] = formatHeader(header)
-			}
-			table.SetHeader(formattedHeaders)
-
-			// Set column alignment
-			table.SetColumnAlignment([]int{
+		sectionKey := fmt.Sprintf("futureProjections_%s_%d", month, year)
+		renderer := session.Section(sectionKey, fmt.Sprintf("Product Comparison Table for %s %d:", month, year))
+		renderer.WriteHeader(headers)
+		if a, ok := renderer.(aligner); ok {
+			a.SetAlignment([]int{
 				tablewriter.ALIGN_DEFAULT, // Product
 				tablewriter.ALIGN_RIGHT,   // Annual Rate
 				tablewriter.ALIGN_RIGHT,   // Monthly Cost
@@ -522,24 +467,28 @@ func printFutureProductComparisons(monthlyData []MonthlyData, productsData []Mon
 
 		type ProductRow struct {
 			Row         []string
-			NetGain     float64
+			NetGain     Money
 			ProductName string
 		}
 
 		var productRows []ProductRow
-		var maxNetGain float64 = -1e9 // Initialize to a very small number
+		maxNetGain := decimal.NewFromInt(-1000000000) // Initialize to a very small number
 
 		// First pass: compute net gains and find the maximum net gain
 		for _, product := range fp.Products {
-			projectedInterest := capital * (product.AnnualRate / 100 / 12)
-			netGain := projectedInterest - product.MonthlyCost
+			months := 1
+			if product.Name == currentProductName {
+				months = monthsOnCurrent + futureIdx + 1
+			}
+			projectedInterest := effectiveInterest(product, capital, months)
+			netGain := projectedInterest.Sub(product.MonthlyCost)
 
 			row := []string{
 				product.Name,
-				fmt.Sprintf("%.2f%%", product.AnnualRate),
-				fmt.Sprintf("%.2f", product.MonthlyCost),
-				fmt.Sprintf("%.2f", projectedInterest),
-				fmt.Sprintf("%.2f", netGain),
+				product.AnnualRate.StringFixed(2) + "%",
+				product.MonthlyCost.StringFixed(2),
+				projectedInterest.StringFixed(2),
+				netGain.StringFixed(2),
 			}
 
 			productRows = append(productRows, ProductRow{
@@ -549,47 +498,33 @@ func printFutureProductComparisons(monthlyData []MonthlyData, productsData []Mon
 			})
 
 			// Update maxNetGain
-			if netGain > maxNetGain {
+			if netGain.GreaterThan(maxNetGain) {
 				maxNetGain = netGain
 			}
 		}
 
-		// Second pass: output the rows with appropriate colors
+		// Second pass: output the rows with appropriate styling
 		for _, pr := range productRows {
 			row := pr.Row
-			if csvOutput {
-				if err := writer.Write(row); err != nil {
-					log.Fatalln("error writing CSV record:", err)
-				}
-			} else {
-				// Create colors slice
-				colors := make([]tablewriter.Colors, len(row))
-				// Color the Net Gain column (index 4)
-				if pr.NetGain == maxNetGain {
-					// Highest net gain, color green
-					colors[4] = tablewriter.Colors{tablewriter.FgGreenColor}
-				} else if pr.NetGain < 0 {
-					// Negative net gain, color red
-					colors[4] = tablewriter.Colors{tablewriter.FgRedColor}
-				} else {
-					colors[4] = tablewriter.Colors{}
-				}
+			styles := make([]CellStyle, len(row))
 
-				// Color the entire row yellow if this is the current plan
-				if pr.ProductName == currentProductName {
-					// Set foreground color yellow for all cells in the row
-					for i := 0; i < len(row); i++ {
-						colors[i] = tablewriter.Colors{tablewriter.FgHiYellowColor}
-					}
-				}
+			// Style the Net Gain column (index 4)
+			if pr.NetGain.Equal(maxNetGain) {
+				styles[4] = StyleGood
+			} else if pr.NetGain.IsNegative() {
+				styles[4] = StyleBad
+			}
 
-				table.Rich(row, colors)
+			// Highlight the entire row if this is the current plan
+			if pr.ProductName == currentProductName {
+				for i := range styles {
+					styles[i] = StyleHighlight
+				}
 			}
-		}
 
-		if !csvOutput {
-			fmt.Printf("\nProduct Comparison Table for %s %d:\n", month, year)
-			table.Render()
+			renderer.WriteRow(row, styles)
 		}
+
+		renderer.Flush()
 	}
 }
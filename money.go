@@ -0,0 +1,23 @@
+package main
+
+import "github.com/shopspring/decimal"
+
+// Money is the fixed-precision type used for every monetary and rate value
+// in this package. It is a plain alias for decimal.Decimal so that all of
+// decimal's arithmetic and JSON (un)marshaling methods are usable directly,
+// avoiding the rounding drift float64 accumulates once capital is derived
+// via division and then compounded across months.
+type Money = decimal.Decimal
+
+var (
+	// hundred and twelve are reused constantly when turning an annual
+	// percentage rate into a monthly fraction (rate/100/12).
+	hundred = decimal.NewFromInt(100)
+	twelve  = decimal.NewFromInt(12)
+)
+
+// monthlyRateFraction converts an annual percentage rate (e.g. 4.5 for 4.5%)
+// into the monthly decimal fraction used throughout the interest math.
+func monthlyRateFraction(annualRate Money) Money {
+	return annualRate.Div(hundred).Div(twelve)
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Global flag variables for the project subcommand.
+var (
+	inflationRate  float64
+	monthlyDeposit float64
+)
+
+func newProjectCmd() *cobra.Command {
+	projectCmd := &cobra.Command{
+		Use:   "project",
+		Short: "Project future capital across products in inflation-adjusted terms",
+		Run: func(cmd *cobra.Command, args []string) {
+			if !validOutputFormats[outputFormat] {
+				log.Fatalf("invalid --format %q (want table|csv|json|md|html)", outputFormat)
+			}
+
+			monthlyData, err := loadDataFromJSON(jsonFileName)
+			if err != nil {
+				log.Fatalf("Error loading JSON data: %v", err)
+			}
+
+			productsData, err := loadProductsFromJSON(productsFileName)
+			if err != nil {
+				log.Fatalf("Error loading products data: %v", err)
+			}
+
+			session := newRenderSession(outputFormat)
+			printInflationAdjustedProjections(monthlyData, productsData, inflationRate, decimal.NewFromFloat(monthlyDeposit), session)
+			session.Finish()
+		},
+	}
+
+	projectCmd.Flags().Float64Var(&inflationRate, "inflation-rate", 0.0, "annual inflation rate (percent) used to discount projected capital to real terms")
+	projectCmd.Flags().Float64Var(&monthlyDeposit, "monthly-deposit", 0.0, "amount added to capital before interest is applied each projected month")
+
+	return projectCmd
+}
+
+// projectionMonth is one future month's product listing, sorted by date so
+// projections can walk forward from the present.
+type projectionMonth struct {
+	Date     time.Time
+	Products []Product
+}
+
+// productProjection is the simulated outcome of staying on one product for
+// the whole projection horizon.
+type productProjection struct {
+	ProductName        string
+	NominalFinal       Money
+	RealFinal          Money
+	CumulativeInterest Money
+	CumulativeCosts    Money
+	BreakEvenMonth     int // 1-based month index net gain first exceeds the baseline's; 0 if never
+}
+
+// printInflationAdjustedProjections simulates every product forward across
+// the known future months, depositing monthlyDepositAmount and applying
+// each product's own rate/cost before discounting the nominal result by
+// inflationRateAnnual to produce a "real" capital figure comparable across
+// products.
+func printInflationAdjustedProjections(monthlyData []MonthlyData, productsData []MonthlyProducts, inflationRateAnnual float64, monthlyDepositAmount Money, session *renderSession) {
+	lastData := monthlyData[len(monthlyData)-1]
+	lastDateStr := fmt.Sprintf("%d-%s", lastData.Year, lastData.Month)
+	lastDate, err := time.Parse("2006-January", lastDateStr)
+	if err != nil {
+		fmt.Printf("Error parsing date '%s': %v\n", lastDateStr, err)
+		return
+	}
+
+	// Resolve the last month's product definition as of its exact date so
+	// mid-month rate changes are honored, then invert its actually-applicable
+	// tiered/intro rate to recover startCapital, not the flat product rate.
+	currentProduct, ok := resolveProductAsOf(productsData, lastData.CurrentProductName, lastDate)
+	if !ok || currentProduct.AnnualRate.IsZero() {
+		fmt.Printf("Current rate not found for last month (%s %d)\n", lastData.Month, lastData.Year)
+		return
+	}
+	startCapital := inverseEffectiveInterest(currentProduct, lastData.ActualInterest, monthsOnProduct(monthlyData, len(monthlyData)-1))
+
+	var months []projectionMonth
+	for _, mp := range productsData {
+		dateStr := fmt.Sprintf("%d-%s", mp.Year, mp.Month)
+		date, err := time.Parse("2006-January", dateStr)
+		if err != nil {
+			fmt.Printf("Error parsing date '%s': %v\n", dateStr, err)
+			continue
+		}
+		if date.After(lastDate) {
+			months = append(months, projectionMonth{Date: date, Products: mp.Products})
+		}
+	}
+
+	if len(months) == 0 {
+		fmt.Println("No future products found.")
+		return
+	}
+
+	sort.Slice(months, func(i, j int) bool {
+		return months[i].Date.Before(months[j].Date)
+	})
+
+	productNames := collectProductNames(months)
+
+	_, _, _, baselineTrajectory := simulateProduct(startCapital, months, lastData.CurrentProductName, monthlyDepositAmount)
+
+	var projections []productProjection
+	for _, name := range productNames {
+		final, cumulativeInterest, cumulativeCosts, trajectory := simulateProduct(startCapital, months, name, monthlyDepositAmount)
+
+		discountFactor := math.Pow(1+inflationRateAnnual/100/12, float64(len(months)))
+		real := final.Div(decimal.NewFromFloat(discountFactor))
+
+		projections = append(projections, productProjection{
+			ProductName:        name,
+			NominalFinal:       final,
+			RealFinal:          real,
+			CumulativeInterest: cumulativeInterest,
+			CumulativeCosts:    cumulativeCosts,
+			BreakEvenMonth:     breakEvenMonth(trajectory, baselineTrajectory),
+		})
+	}
+
+	renderProjections(projections, session)
+}
+
+// collectProductNames returns the union of product names seen across
+// months, in first-seen order, so every product that appears at any point
+// in the projection horizon is simulated.
+func collectProductNames(months []projectionMonth) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, m := range months {
+		for _, p := range m.Products {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				names = append(names, p.Name)
+			}
+		}
+	}
+	return names
+}
+
+// simulateProduct walks months forward, depositing monthlyDepositAmount and
+// applying productName's tiers/intro rate and cost for that month before
+// compounding. If a month has no listing for productName, the last known
+// product definition carries forward. It returns the final capital,
+// cumulative interest and costs, and the running net-gain trajectory (one
+// entry per month).
+func simulateProduct(startCapital Money, months []projectionMonth, productName string, monthlyDepositAmount Money) (final, cumulativeInterest, cumulativeCosts Money, trajectory []Money) {
+	capital := startCapital
+	var lastProduct Product
+	monthsOnProduct := 0
+
+	for _, m := range months {
+		product := lastProduct
+		for _, p := range m.Products {
+			if p.Name == productName {
+				product = p
+				break
+			}
+		}
+		lastProduct = product
+		monthsOnProduct++
+
+		capital = capital.Add(monthlyDepositAmount)
+		interest := effectiveInterest(product, capital, monthsOnProduct)
+		capital = capital.Add(interest).Sub(product.MonthlyCost)
+
+		cumulativeInterest = cumulativeInterest.Add(interest)
+		cumulativeCosts = cumulativeCosts.Add(product.MonthlyCost)
+		trajectory = append(trajectory, cumulativeInterest.Sub(cumulativeCosts))
+	}
+
+	return capital, cumulativeInterest, cumulativeCosts, trajectory
+}
+
+// breakEvenMonth returns the first 1-based month index at which trajectory's
+// net gain exceeds baseline's, or 0 if it never does.
+func breakEvenMonth(trajectory, baseline []Money) int {
+	for i := range trajectory {
+		if i >= len(baseline) {
+			break
+		}
+		if trajectory[i].GreaterThan(baseline[i]) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func renderProjections(projections []productProjection, session *renderSession) {
+	headers := []string{"Product", "Nominal Final Capital", "Real Final Capital", "Cumulative Net Interest", "Cumulative Costs", "Break-even Month"}
+
+	renderer := session.Section("inflationAdjustedProjections", "Inflation-Adjusted Projections:")
+	renderer.WriteHeader(headers)
+	if a, ok := renderer.(aligner); ok {
+		a.SetAlignment([]int{
+			tablewriter.ALIGN_DEFAULT,
+			tablewriter.ALIGN_RIGHT,
+			tablewriter.ALIGN_RIGHT,
+			tablewriter.ALIGN_RIGHT,
+			tablewriter.ALIGN_RIGHT,
+			tablewriter.ALIGN_RIGHT,
+		})
+	}
+
+	for _, p := range projections {
+		breakEven := "never"
+		if p.BreakEvenMonth > 0 {
+			breakEven = fmt.Sprintf("%d", p.BreakEvenMonth)
+		}
+		renderer.WriteRow([]string{
+			p.ProductName,
+			p.NominalFinal.StringFixed(2),
+			p.RealFinal.StringFixed(2),
+			p.CumulativeInterest.StringFixed(2),
+			p.CumulativeCosts.StringFixed(2),
+			breakEven,
+		}, nil)
+	}
+
+	renderer.Flush()
+}
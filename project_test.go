@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBreakEvenMonth(t *testing.T) {
+	cases := []struct {
+		name       string
+		trajectory []Money
+		baseline   []Money
+		want       int
+	}{
+		{
+			name:       "never catches up",
+			trajectory: []Money{decimalFromFloat(1), decimalFromFloat(2)},
+			baseline:   []Money{decimalFromFloat(5), decimalFromFloat(6)},
+			want:       0,
+		},
+		{
+			name:       "overtakes on the first month",
+			trajectory: []Money{decimalFromFloat(10)},
+			baseline:   []Money{decimalFromFloat(5)},
+			want:       1,
+		},
+		{
+			name:       "overtakes partway through",
+			trajectory: []Money{decimalFromFloat(1), decimalFromFloat(2), decimalFromFloat(10)},
+			baseline:   []Money{decimalFromFloat(5), decimalFromFloat(6), decimalFromFloat(7)},
+			want:       3,
+		},
+		{
+			name:       "trajectory shorter than baseline",
+			trajectory: []Money{decimalFromFloat(1)},
+			baseline:   []Money{decimalFromFloat(5), decimalFromFloat(0)},
+			want:       0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := breakEvenMonth(tc.trajectory, tc.baseline); got != tc.want {
+				t.Errorf("breakEvenMonth() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSimulateProduct(t *testing.T) {
+	flat := Product{Name: "Flat", AnnualRate: decimalFromFloat(12), MonthlyCost: decimalFromFloat(1)}
+	months := []projectionMonth{
+		{Date: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), Products: []Product{flat}},
+		{Date: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Products: []Product{flat}},
+	}
+
+	final, cumulativeInterest, cumulativeCosts, trajectory := simulateProduct(decimalFromFloat(1000), months, "Flat", decimal.Zero)
+
+	// Month 1: 1000 * 1% = 10 interest, minus 1 cost -> capital 1009.
+	// Month 2: 1009 * 1% = 10.09 interest, minus 1 cost -> capital 1018.09.
+	wantFinal := decimalFromFloat(1018.09)
+	if !final.Equal(wantFinal) {
+		t.Errorf("final = %s, want %s", final, wantFinal)
+	}
+
+	wantInterest := decimalFromFloat(20.09)
+	if !cumulativeInterest.Equal(wantInterest) {
+		t.Errorf("cumulativeInterest = %s, want %s", cumulativeInterest, wantInterest)
+	}
+
+	wantCosts := decimalFromFloat(2)
+	if !cumulativeCosts.Equal(wantCosts) {
+		t.Errorf("cumulativeCosts = %s, want %s", cumulativeCosts, wantCosts)
+	}
+
+	if len(trajectory) != 2 {
+		t.Fatalf("len(trajectory) = %d, want 2", len(trajectory))
+	}
+}
+
+func TestSimulateProductCarriesForwardLastKnownProduct(t *testing.T) {
+	flat := Product{Name: "Flat", AnnualRate: decimalFromFloat(12)}
+	months := []projectionMonth{
+		{Date: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), Products: []Product{flat}},
+		{Date: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Products: nil}, // no listing this month
+	}
+
+	_, cumulativeInterest, _, _ := simulateProduct(decimalFromFloat(1000), months, "Flat", decimal.Zero)
+
+	// Both months should earn interest at Flat's rate even though the
+	// second month has no matching listing: 1000 * 1% = 10, then 1010 * 1% = 10.1.
+	if want := decimalFromFloat(20.1); !cumulativeInterest.Equal(want) {
+		t.Errorf("cumulativeInterest = %s, want %s when the last known product carries forward", cumulativeInterest, want)
+	}
+}
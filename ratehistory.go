@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/btree"
+)
+
+// rateChange is a single point-in-time rate change, ordered by Date within
+// a RateHistory's B-tree so the rate in effect on any date can be found,
+// down to the day a MonthlyProducts entry specifies.
+type rateChange struct {
+	Date time.Time
+	Rate Money
+}
+
+func (c rateChange) Less(than btree.Item) bool {
+	return c.Date.Before(than.(rateChange).Date)
+}
+
+// RateHistory resolves the rate that was in effect for a product on a given
+// date, so mid-month rate changes and promotional periods can be modeled:
+// adding a second MonthlyProducts entry for the same Year/Month with a later
+// Day records a change partway through the month instead of assuming one
+// flat rate per calendar month.
+type RateHistory interface {
+	// Add records a rate taking effect from date.
+	Add(date time.Time, rate Money)
+	// DescendFirstLessOrEqual returns the rate whose effective date is the
+	// latest one on or before date, i.e. the rate in effect on that date.
+	DescendFirstLessOrEqual(date time.Time) (Money, bool)
+}
+
+// btreeRateHistory is the B-tree-backed RateHistory implementation.
+type btreeRateHistory struct {
+	tree *btree.BTree
+}
+
+// NewRateHistory returns an empty, B-tree-backed RateHistory.
+func NewRateHistory() RateHistory {
+	return &btreeRateHistory{tree: btree.New(32)}
+}
+
+func (h *btreeRateHistory) Add(date time.Time, rate Money) {
+	h.tree.ReplaceOrInsert(rateChange{Date: date, Rate: rate})
+}
+
+func (h *btreeRateHistory) DescendFirstLessOrEqual(date time.Time) (Money, bool) {
+	var match rateChange
+	found := false
+	h.tree.DescendLessOrEqual(rateChange{Date: date}, func(item btree.Item) bool {
+		match = item.(rateChange)
+		found = true
+		return false
+	})
+	return match.Rate, found
+}
+
+// buildRateHistories indexes every product's rate across all months of
+// productsData, keyed by product name, so callers can resolve the rate in
+// effect for a product on an arbitrary date instead of only on exact
+// year-month matches.
+func buildRateHistories(productsData []MonthlyProducts) map[string]RateHistory {
+	histories := make(map[string]RateHistory)
+
+	for _, mp := range productsData {
+		date, err := monthlyProductsDate(mp)
+		if err != nil {
+			continue
+		}
+
+		for _, product := range mp.Products {
+			history, ok := histories[product.Name]
+			if !ok {
+				history = NewRateHistory()
+				histories[product.Name] = history
+			}
+			history.Add(date, product.AnnualRate)
+		}
+	}
+
+	return histories
+}
+
+// monthlyProductsDate parses mp's effective date, defaulting Day to the 1st
+// when it isn't set so a mid-month rate change can be expressed by adding a
+// second entry for the same Year/Month with a later Day.
+func monthlyProductsDate(mp MonthlyProducts) (time.Time, error) {
+	date, err := time.Parse("2006-January", fmt.Sprintf("%d-%s", mp.Year, mp.Month))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if mp.Day > 0 {
+		date = time.Date(date.Year(), date.Month(), mp.Day, 0, 0, 0, 0, time.UTC)
+	}
+	return date, nil
+}
+
+// resolveProductAsOf returns the definition of the named product from the
+// latest MonthlyProducts entry on or before asOf, so its tiers and intro
+// rate are resolved at the same granularity RateHistory resolves the flat
+// rate, instead of assuming the product's fields never change.
+func resolveProductAsOf(productsData []MonthlyProducts, name string, asOf time.Time) (Product, bool) {
+	var best Product
+	var bestDate time.Time
+	found := false
+
+	for _, mp := range productsData {
+		date, err := monthlyProductsDate(mp)
+		if err != nil || date.After(asOf) {
+			continue
+		}
+		for _, product := range mp.Products {
+			if product.Name != name {
+				continue
+			}
+			if !found || date.After(bestDate) {
+				best = product
+				bestDate = date
+				found = true
+			}
+		}
+	}
+
+	return best, found
+}
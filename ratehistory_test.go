@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBuildRateHistoriesMidMonthChange(t *testing.T) {
+	productsData := []MonthlyProducts{
+		{
+			Year: 2024, Month: "March",
+			Products: []Product{{Name: "BoostBank", AnnualRate: decimal.NewFromFloat(4)}},
+		},
+		{
+			Year: 2024, Month: "March", Day: 15,
+			Products: []Product{{Name: "BoostBank", AnnualRate: decimal.NewFromFloat(5)}},
+		},
+	}
+
+	histories := buildRateHistories(productsData)
+	history, ok := histories["BoostBank"]
+	if !ok {
+		t.Fatalf("expected a history for BoostBank")
+	}
+
+	beforeChange, err := monthlyProductsDate(MonthlyProducts{Year: 2024, Month: "March", Day: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate, found := history.DescendFirstLessOrEqual(beforeChange); !found || !rate.Equal(decimal.NewFromFloat(4)) {
+		t.Errorf("rate before mid-month change = %v, found=%v, want 4", rate, found)
+	}
+
+	afterChange, err := monthlyProductsDate(MonthlyProducts{Year: 2024, Month: "March", Day: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate, found := history.DescendFirstLessOrEqual(afterChange); !found || !rate.Equal(decimal.NewFromFloat(5)) {
+		t.Errorf("rate after mid-month change = %v, found=%v, want 5", rate, found)
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// CellStyle marks how a cell should be highlighted, independent of the
+// destination format: ANSI color for a terminal table, a CSS class for
+// HTML, and ignored by CSV/JSON/Markdown.
+type CellStyle int
+
+const (
+	StyleNone CellStyle = iota
+	StyleGood
+	StyleBad
+	StyleWarn
+	StyleHighlight
+)
+
+// Renderer is implemented by every supported output format, so the print*
+// functions build a table once and let --format decide how it's written.
+type Renderer interface {
+	WriteHeader(headers []string)
+	WriteRow(cells []string, styles []CellStyle)
+	Flush()
+}
+
+// aligner is implemented only by renderers where column alignment is
+// meaningful (currently just the ANSI table).
+type aligner interface {
+	SetAlignment(alignment []int)
+}
+
+// validOutputFormats are the values accepted by --format.
+var validOutputFormats = map[string]bool{
+	"table": true,
+	"csv":   true,
+	"json":  true,
+	"md":    true,
+	"html":  true,
+}
+
+// renderSession accumulates output across every section printed during one
+// command invocation. table/csv/md sections stream to stdout as soon as
+// they're flushed; json and html instead buffer into a single combined
+// document that Finish writes out once every section is done.
+type renderSession struct {
+	format  string
+	jsonDoc map[string]interface{}
+	html    strings.Builder
+}
+
+func newRenderSession(format string) *renderSession {
+	return &renderSession{format: format, jsonDoc: make(map[string]interface{})}
+}
+
+// Section returns a Renderer for one named table within this session. key
+// identifies the section in the JSON document; title is the human-readable
+// heading used by the table/markdown/html renderers.
+func (s *renderSession) Section(key, title string) Renderer {
+	switch s.format {
+	case "csv":
+		return &csvRenderer{}
+	case "json":
+		return &jsonRenderer{session: s, key: key}
+	case "md":
+		return &markdownRenderer{title: title}
+	case "html":
+		return &htmlRenderer{session: s, title: title}
+	default:
+		return &tableRenderer{title: title}
+	}
+}
+
+// Finish writes whatever the format needs once every section has been
+// rendered: the combined JSON document, or the self-contained HTML file.
+func (s *renderSession) Finish() {
+	switch s.format {
+	case "json":
+		data, err := json.MarshalIndent(s.jsonDoc, "", "  ")
+		if err != nil {
+			log.Fatalf("error marshaling JSON output: %v", err)
+		}
+		fmt.Println(string(data))
+	case "html":
+		doc := "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Interest Report</title><style>" +
+			htmlCSS + "</style></head><body>\n" + s.html.String() + "</body></html>\n"
+		if err := os.WriteFile("report.html", []byte(doc), 0o644); err != nil {
+			log.Fatalf("error writing report.html: %v", err)
+		}
+		fmt.Println("Wrote report.html")
+	}
+}
+
+// tableRenderer renders an ANSI table to stdout - the tool's original
+// default behavior.
+type tableRenderer struct {
+	title string
+	table *tablewriter.Table
+}
+
+func (r *tableRenderer) WriteHeader(headers []string) {
+	r.table = tablewriter.NewWriter(os.Stdout)
+	formatted := make([]string, len(headers))
+	for i, h := range headers {
+		formatted[i] = formatHeader(h)
+	}
+	r.table.SetHeader(formatted)
+}
+
+func (r *tableRenderer) SetAlignment(alignment []int) {
+	r.table.SetColumnAlignment(alignment)
+}
+
+func (r *tableRenderer) WriteRow(cells []string, styles []CellStyle) {
+	colors := make([]tablewriter.Colors, len(cells))
+	for i := range cells {
+		if i < len(styles) {
+			colors[i] = ansiColorFor(styles[i])
+		}
+	}
+	r.table.Rich(cells, colors)
+}
+
+func (r *tableRenderer) Flush() {
+	if r.title != "" {
+		fmt.Println(r.title)
+	}
+	r.table.Render()
+}
+
+func ansiColorFor(style CellStyle) tablewriter.Colors {
+	switch style {
+	case StyleBad:
+		return tablewriter.Colors{tablewriter.FgRedColor}
+	case StyleWarn:
+		return tablewriter.Colors{tablewriter.FgYellowColor}
+	case StyleGood:
+		return tablewriter.Colors{tablewriter.FgGreenColor}
+	case StyleHighlight:
+		return tablewriter.Colors{tablewriter.FgHiYellowColor}
+	default:
+		return tablewriter.Colors{}
+	}
+}
+
+// csvRenderer renders CSV to stdout - the tool's original --csv behavior.
+type csvRenderer struct {
+	writer *csv.Writer
+}
+
+func (r *csvRenderer) WriteHeader(headers []string) {
+	r.writer = csv.NewWriter(os.Stdout)
+	if err := r.writer.Write(headers); err != nil {
+		log.Fatalln("error writing CSV headers:", err)
+	}
+}
+
+func (r *csvRenderer) WriteRow(cells []string, styles []CellStyle) {
+	if err := r.writer.Write(cells); err != nil {
+		log.Fatalln("error writing CSV record:", err)
+	}
+}
+
+func (r *csvRenderer) Flush() {
+	r.writer.Flush()
+}
+
+// jsonRenderer buffers rows as header-keyed objects and hands them to the
+// session under r.key, so the whole command's output ends up as one JSON
+// document keyed by section.
+type jsonRenderer struct {
+	session *renderSession
+	key     string
+	headers []string
+	rows    []map[string]string
+}
+
+func (r *jsonRenderer) WriteHeader(headers []string) {
+	r.headers = headers
+}
+
+func (r *jsonRenderer) WriteRow(cells []string, styles []CellStyle) {
+	row := make(map[string]string, len(cells))
+	for i, cell := range cells {
+		if i < len(r.headers) {
+			row[r.headers[i]] = cell
+		}
+	}
+	r.rows = append(r.rows, row)
+}
+
+func (r *jsonRenderer) Flush() {
+	r.session.jsonDoc[r.key] = r.rows
+}
+
+// markdownRenderer renders a GitHub-flavored Markdown table suitable for
+// pasting into notes.
+type markdownRenderer struct {
+	title   string
+	headers []string
+	rows    [][]string
+}
+
+func (r *markdownRenderer) WriteHeader(headers []string) {
+	r.headers = headers
+}
+
+func (r *markdownRenderer) WriteRow(cells []string, styles []CellStyle) {
+	r.rows = append(r.rows, cells)
+}
+
+func (r *markdownRenderer) Flush() {
+	if r.title != "" {
+		fmt.Printf("\n## %s\n\n", r.title)
+	}
+	fmt.Println("| " + strings.Join(r.headers, " | ") + " |")
+	sep := make([]string, len(r.headers))
+	for i := range sep {
+		sep[i] = "---"
+	}
+	fmt.Println("| " + strings.Join(sep, " | ") + " |")
+	for _, row := range r.rows {
+		fmt.Println("| " + strings.Join(row, " | ") + " |")
+	}
+}
+
+// htmlRenderer appends a <table> for this section into the session's
+// shared HTML buffer, using CSS classes for the same red/yellow/green
+// semantic coloring the ANSI table uses.
+type htmlRenderer struct {
+	session *renderSession
+	title   string
+}
+
+func (r *htmlRenderer) WriteHeader(headers []string) {
+	r.session.html.WriteString(fmt.Sprintf("<h2>%s</h2>\n<table>\n<tr>", htmlEscape(r.title)))
+	for _, h := range headers {
+		r.session.html.WriteString("<th>" + htmlEscape(h) + "</th>")
+	}
+	r.session.html.WriteString("</tr>\n")
+}
+
+func (r *htmlRenderer) WriteRow(cells []string, styles []CellStyle) {
+	r.session.html.WriteString("<tr>")
+	for i, cell := range cells {
+		class := ""
+		if i < len(styles) {
+			class = cssClassFor(styles[i])
+		}
+		if class != "" {
+			r.session.html.WriteString(fmt.Sprintf("<td class=\"%s\">%s</td>", class, htmlEscape(cell)))
+		} else {
+			r.session.html.WriteString("<td>" + htmlEscape(cell) + "</td>")
+		}
+	}
+	r.session.html.WriteString("</tr>\n")
+}
+
+func (r *htmlRenderer) Flush() {
+	r.session.html.WriteString("</table>\n")
+}
+
+func cssClassFor(style CellStyle) string {
+	switch style {
+	case StyleBad:
+		return "neg"
+	case StyleWarn:
+		return "warn"
+	case StyleGood:
+		return "pos"
+	case StyleHighlight:
+		return "current"
+	default:
+		return ""
+	}
+}
+
+func htmlEscape(s string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return replacer.Replace(s)
+}
+
+const htmlCSS = `
+table { border-collapse: collapse; margin-bottom: 2em; font-family: sans-serif; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.neg { color: #c0392b; }
+.warn { color: #d4ac0d; }
+.pos { color: #27ae60; font-weight: bold; }
+.current { background: #fdf6e3; }
+`
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// reportsDir is where SummaryReport runs are persisted.
+const reportsDir = "reports"
+
+// SummaryReport captures whole-timeline performance and risk metrics for a
+// plan, so a user can judge with one number whether their current choice of
+// product is actually working.
+type SummaryReport struct {
+	RunID                string    `json:"runID"`
+	GeneratedAt          time.Time `json:"generatedAt"`
+	StartYear            int       `json:"startYear"`
+	StartMonth           string    `json:"startMonth"`
+	EndYear              int       `json:"endYear"`
+	EndMonth             string    `json:"endMonth"`
+	TotalDeposits        float64   `json:"totalDeposits"`
+	TotalInterest        float64   `json:"totalInterest"`
+	TotalCosts           float64   `json:"totalCosts"`
+	NetGain              float64   `json:"netGain"`
+	CAGR                 float64   `json:"cagr"`
+	AnnualizedVolatility float64   `json:"annualizedVolatility"`
+	SharpeRatio          float64   `json:"sharpeRatio"`
+	SortinoRatio         float64   `json:"sortinoRatio"`
+	MaxDrawdown          float64   `json:"maxDrawdown"`
+	AvgDrawdown          float64   `json:"avgDrawdown"`
+}
+
+// ReportIndexEntry is a single line in the ReportIndex, letting users diff
+// runs over time without re-reading every report file.
+type ReportIndexEntry struct {
+	RunID       string    `json:"runID"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Path        string    `json:"path"`
+}
+
+// ReportIndex lists every SummaryReport run written to reportsDir.
+type ReportIndex struct {
+	Runs []ReportIndexEntry `json:"runs"`
+}
+
+// Global flag variable for the report subcommand.
+var riskFreeRate float64
+
+func newReportCmd() *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Generate a portfolio summary report with risk metrics",
+		Run: func(cmd *cobra.Command, args []string) {
+			if !validOutputFormats[outputFormat] {
+				log.Fatalf("invalid --format %q (want table|csv|json|md|html)", outputFormat)
+			}
+
+			monthlyData, err := loadDataFromJSON(jsonFileName)
+			if err != nil {
+				log.Fatalf("Error loading JSON data: %v", err)
+			}
+
+			productsData, err := loadProductsFromJSON(productsFileName)
+			if err != nil {
+				log.Fatalf("Error loading products data: %v", err)
+			}
+
+			report, err := computeSummaryReport(monthlyData, productsData, riskFreeRate)
+			if err != nil {
+				log.Fatalf("Error computing summary report: %v", err)
+			}
+
+			if err := persistReport(report); err != nil {
+				log.Fatalf("Error persisting report: %v", err)
+			}
+
+			session := newRenderSession(outputFormat)
+			printSummaryReport(report, session)
+			session.Finish()
+		},
+	}
+
+	reportCmd.Flags().Float64Var(&riskFreeRate, "risk-free-rate", 0.0, "annual risk-free rate (percent) used for the Sharpe ratio")
+
+	return reportCmd
+}
+
+// capitalPoint is one month's derived capital, used to compute the
+// volatility, drawdown and growth metrics in a SummaryReport. Capital is
+// kept as Money through the division and compounding that derives it, and
+// only converted to float64 once it feeds the statistical ratios below.
+type capitalPoint struct {
+	Year           int
+	Month          string
+	Capital        Money
+	ActualInterest Money
+	MonthlyCost    Money
+}
+
+// computeCapitalSeries derives the capital at the end of each month the same
+// way printInterestComparisonTable does: by inverting ActualInterest through
+// the tiered/intro rate that was actually in effect for the product.
+func computeCapitalSeries(monthlyData []MonthlyData, productsData []MonthlyProducts) ([]capitalPoint, error) {
+	productsMap := make(map[string][]Product)
+	for _, mp := range productsData {
+		key := fmt.Sprintf("%d-%s", mp.Year, mp.Month)
+		productsMap[key] = mp.Products
+	}
+
+	var series []capitalPoint
+	for idx, data := range monthlyData {
+		key := fmt.Sprintf("%d-%s", data.Year, data.Month)
+		products, ok := productsMap[key]
+		if !ok {
+			continue
+		}
+
+		var currentProduct Product
+		var currentMonthlyCost Money
+		found := false
+		for _, product := range products {
+			if product.Name == data.CurrentProductName {
+				currentProduct = product
+				currentMonthlyCost = product.MonthlyCost
+				found = true
+				break
+			}
+		}
+
+		if !found || currentProduct.AnnualRate.IsZero() {
+			continue
+		}
+
+		capital := inverseEffectiveInterest(currentProduct, data.ActualInterest, monthsOnProduct(monthlyData, idx))
+		series = append(series, capitalPoint{
+			Year:           data.Year,
+			Month:          data.Month,
+			Capital:        capital,
+			ActualInterest: data.ActualInterest,
+			MonthlyCost:    currentMonthlyCost,
+		})
+	}
+
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no months with matching products found")
+	}
+
+	return series, nil
+}
+
+// computeSummaryReport walks the full capital series and derives the
+// portfolio-level metrics described in SummaryReport.
+func computeSummaryReport(monthlyData []MonthlyData, productsData []MonthlyProducts, riskFreeRateAnnual float64) (*SummaryReport, error) {
+	series, err := computeCapitalSeries(monthlyData, productsData)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SummaryReport{
+		StartYear:  series[0].Year,
+		StartMonth: series[0].Month,
+		EndYear:    series[len(series)-1].Year,
+		EndMonth:   series[len(series)-1].Month,
+	}
+
+	var monthlyReturns []float64
+	var previousCapital Money
+	peak := series[0].Capital
+	var drawdowns []float64
+
+	var totalDeposits, totalInterest, totalCosts Money
+	for idx, point := range series {
+		totalInterest = totalInterest.Add(point.ActualInterest)
+		totalCosts = totalCosts.Add(point.MonthlyCost)
+
+		if idx > 0 {
+			deposit := point.Capital.Sub(previousCapital).Sub(point.ActualInterest)
+			totalDeposits = totalDeposits.Add(deposit)
+
+			if !previousCapital.IsZero() {
+				monthlyReturns = append(monthlyReturns, point.ActualInterest.Div(previousCapital).InexactFloat64())
+			}
+		}
+		previousCapital = point.Capital
+
+		if point.Capital.GreaterThan(peak) {
+			peak = point.Capital
+		}
+		if peak.IsPositive() {
+			drawdowns = append(drawdowns, peak.Sub(point.Capital).Div(peak).InexactFloat64())
+		}
+	}
+
+	report.TotalDeposits = totalDeposits.InexactFloat64()
+	report.TotalInterest = totalInterest.InexactFloat64()
+	report.TotalCosts = totalCosts.InexactFloat64()
+	report.NetGain = totalInterest.Sub(totalCosts).InexactFloat64()
+
+	months := len(series) - 1
+	if months > 0 && series[0].Capital.IsPositive() {
+		years := float64(months) / 12
+		ratio := series[len(series)-1].Capital.Div(series[0].Capital).InexactFloat64()
+		report.CAGR = math.Pow(ratio, 1/years) - 1
+	}
+
+	meanReturn := mean(monthlyReturns)
+	report.AnnualizedVolatility = stddev(monthlyReturns, meanReturn) * math.Sqrt(12)
+
+	riskFreeMonthly := riskFreeRateAnnual / 100 / 12
+	if report.AnnualizedVolatility > 0 {
+		report.SharpeRatio = (meanReturn - riskFreeMonthly) / stddev(monthlyReturns, meanReturn) * math.Sqrt(12)
+	}
+
+	downsideDeviation := downsideStddev(monthlyReturns, riskFreeMonthly)
+	if downsideDeviation > 0 {
+		report.SortinoRatio = (meanReturn - riskFreeMonthly) / downsideDeviation * math.Sqrt(12)
+	}
+
+	report.MaxDrawdown = maxOf(drawdowns)
+	report.AvgDrawdown = mean(drawdowns)
+
+	return report, nil
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func stddev(values []float64, meanValue float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sumSquares float64
+	for _, v := range values {
+		diff := v - meanValue
+		sumSquares += diff * diff
+	}
+	return math.Sqrt(sumSquares / float64(len(values)))
+}
+
+func downsideStddev(values []float64, threshold float64) float64 {
+	var downside []float64
+	for _, v := range values {
+		if v < threshold {
+			downside = append(downside, v-threshold)
+		}
+	}
+	if len(downside) == 0 {
+		return 0
+	}
+	var sumSquares float64
+	for _, v := range downside {
+		sumSquares += v * v
+	}
+	return math.Sqrt(sumSquares / float64(len(downside)))
+}
+
+func maxOf(values []float64) float64 {
+	var max float64
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// persistReport writes report as a timestamped JSON file under reportsDir
+// and appends it to the ReportIndex so prior runs can be diffed later.
+func persistReport(report *SummaryReport) error {
+	if err := os.MkdirAll(reportsDir, 0o755); err != nil {
+		return err
+	}
+
+	report.RunID = time.Now().Format("20060102-150405")
+	report.GeneratedAt = time.Now()
+
+	fileName := fmt.Sprintf("%s.json", report.RunID)
+	path := filepath.Join(reportsDir, fileName)
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+
+	return appendToReportIndex(ReportIndexEntry{
+		RunID:       report.RunID,
+		GeneratedAt: report.GeneratedAt,
+		Path:        path,
+	})
+}
+
+func appendToReportIndex(entry ReportIndexEntry) error {
+	indexPath := filepath.Join(reportsDir, "index.json")
+
+	var index ReportIndex
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := json.Unmarshal(data, &index); err != nil {
+			return err
+		}
+	}
+
+	index.Runs = append(index.Runs, entry)
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(indexPath, data, 0o644)
+}
+
+func printSummaryReport(report *SummaryReport, session *renderSession) {
+	headers := []string{"Metric", "Value"}
+	rows := [][]string{
+		{"Run ID", report.RunID},
+		{"Start", fmt.Sprintf("%s %d", report.StartMonth, report.StartYear)},
+		{"End", fmt.Sprintf("%s %d", report.EndMonth, report.EndYear)},
+		{"Total Deposits", fmt.Sprintf("%.2f", report.TotalDeposits)},
+		{"Total Interest", fmt.Sprintf("%.2f", report.TotalInterest)},
+		{"Total Plan Costs", fmt.Sprintf("%.2f", report.TotalCosts)},
+		{"Net Gain", fmt.Sprintf("%.2f", report.NetGain)},
+		{"CAGR", fmt.Sprintf("%.2f%%", report.CAGR*100)},
+		{"Annualized Volatility", fmt.Sprintf("%.2f%%", report.AnnualizedVolatility*100)},
+		{"Sharpe Ratio", fmt.Sprintf("%.2f", report.SharpeRatio)},
+		{"Sortino Ratio", fmt.Sprintf("%.2f", report.SortinoRatio)},
+		{"Max Drawdown", fmt.Sprintf("%.2f%%", report.MaxDrawdown*100)},
+		{"Avg Drawdown", fmt.Sprintf("%.2f%%", report.AvgDrawdown*100)},
+	}
+
+	renderer := session.Section("summaryReport", fmt.Sprintf("Summary Report (%s):", report.RunID))
+	renderer.WriteHeader(headers)
+	if a, ok := renderer.(aligner); ok {
+		a.SetAlignment([]int{tablewriter.ALIGN_DEFAULT, tablewriter.ALIGN_RIGHT})
+	}
+	for _, row := range rows {
+		renderer.WriteRow(row, nil)
+	}
+	renderer.Flush()
+}
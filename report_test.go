@@ -0,0 +1,125 @@
+package main
+
+import "testing"
+
+func TestMean(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"single value", []float64{5}, 5},
+		{"multiple values", []float64{1, 2, 3, 4}, 2.5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mean(tc.values); got != tc.want {
+				t.Errorf("mean(%v) = %v, want %v", tc.values, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStddev(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []float64
+		mean   float64
+		want   float64
+	}{
+		{"empty", nil, 0, 0},
+		{"no variance", []float64{2, 2, 2}, 2, 0},
+		{"known variance", []float64{1, 2, 3, 4}, 2.5, 1.118033988749895},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := stddev(tc.values, tc.mean); !floatsClose(got, tc.want) {
+				t.Errorf("stddev(%v, %v) = %v, want %v", tc.values, tc.mean, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDownsideStddev(t *testing.T) {
+	cases := []struct {
+		name      string
+		values    []float64
+		threshold float64
+		want      float64
+	}{
+		{"nothing below threshold", []float64{1, 2, 3}, 0, 0},
+		{"everything below threshold", []float64{-1, -2}, 0, 1.5811388300841898},
+		{"mixed", []float64{-2, 0, 2}, 0, 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := downsideStddev(tc.values, tc.threshold); !floatsClose(got, tc.want) {
+				t.Errorf("downsideStddev(%v, %v) = %v, want %v", tc.values, tc.threshold, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMaxOf(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"all negative", []float64{-3, -1, -2}, 0},
+		{"positive values", []float64{0.1, 0.3, 0.2}, 0.3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := maxOf(tc.values); got != tc.want {
+				t.Errorf("maxOf(%v) = %v, want %v", tc.values, got, tc.want)
+			}
+		})
+	}
+}
+
+func floatsClose(a, b float64) bool {
+	const epsilon = 1e-9
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < epsilon
+}
+
+func TestComputeSummaryReportCAGR(t *testing.T) {
+	// A flat 12% product with no deposits: capital grows 1% in one month,
+	// which annualizes to a CAGR of (1.01^12 - 1).
+	product := Product{Name: "Flat", AnnualRate: decimalFromFloat(12)}
+	productsData := []MonthlyProducts{
+		{Year: 2025, Month: "January", Products: []Product{product}},
+		{Year: 2025, Month: "February", Products: []Product{product}},
+	}
+
+	capital := decimalFromFloat(1000)
+	monthlyData := []MonthlyData{
+		{Year: 2025, Month: "January", CurrentProductName: "Flat", ActualInterest: tieredInterest(product, capital)},
+	}
+	capital = capital.Add(tieredInterest(product, capital))
+	monthlyData = append(monthlyData, MonthlyData{
+		Year: 2025, Month: "February", CurrentProductName: "Flat", ActualInterest: tieredInterest(product, capital),
+	})
+
+	report, err := computeSummaryReport(monthlyData, productsData, 0)
+	if err != nil {
+		t.Fatalf("computeSummaryReport() error = %v", err)
+	}
+
+	// One month elapsed between the two points at a flat 1%/month rate, so
+	// CAGR should equal (1+monthlyRate)^12 - 1.
+	wantCAGR := 0.12682503013196977 // (1.01)^12 - 1
+	if !floatsClose(report.CAGR, wantCAGR) {
+		t.Errorf("CAGR = %v, want %v", report.CAGR, wantCAGR)
+	}
+}
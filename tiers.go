@@ -0,0 +1,139 @@
+package main
+
+import "github.com/shopspring/decimal"
+
+// RateTier is one balance bracket of a piecewise-linear rate schedule. The
+// tier covers the slice of balance between the previous tier's UpToBalance
+// and this one's; a zero UpToBalance marks the top tier ("and above").
+type RateTier struct {
+	UpToBalance Money `json:"upToBalance"`
+	AnnualRate  Money `json:"annualRate"`
+}
+
+// tieredInterest computes one month's interest on balance by walking
+// product's tiers and summing min(balance, tierCap-prevCap) * tierRate/12
+// for each bracket. Products with no tiers fall back to the flat
+// AnnualRate, so existing single-rate products are unaffected.
+func tieredInterest(product Product, balance Money) Money {
+	if len(product.Tiers) == 0 {
+		return balance.Mul(monthlyRateFraction(product.AnnualRate))
+	}
+
+	var interest Money
+	var prevCap Money
+	remaining := balance
+
+	for _, tier := range product.Tiers {
+		if remaining.IsZero() || remaining.IsNegative() {
+			break
+		}
+
+		tierWidth := remaining
+		if !tier.UpToBalance.IsZero() {
+			tierWidth = tier.UpToBalance.Sub(prevCap)
+			if tierWidth.GreaterThan(remaining) {
+				tierWidth = remaining
+			}
+		}
+		if tierWidth.IsNegative() {
+			tierWidth = decimal.Zero
+		}
+
+		interest = interest.Add(tierWidth.Mul(monthlyRateFraction(tier.AnnualRate)))
+		remaining = remaining.Sub(tierWidth)
+		prevCap = tier.UpToBalance
+	}
+
+	return interest
+}
+
+// effectiveInterest returns one month's interest on balance for product,
+// honoring a promotional IntroRate for the first IntroMonths the user has
+// been on it and falling back to the tiered (or flat) rate afterward.
+func effectiveInterest(product Product, balance Money, monthsOnProduct int) Money {
+	if product.IntroMonths > 0 && monthsOnProduct <= product.IntroMonths && !product.IntroRate.IsZero() {
+		return balance.Mul(monthlyRateFraction(product.IntroRate))
+	}
+	return tieredInterest(product, balance)
+}
+
+// inverseTieredInterest is tieredInterest's inverse: given interest actually
+// earned, it recovers the balance that produced it by walking the same
+// tiers and accumulating each one's maximum possible interest contribution
+// until interest is exhausted. Like tieredInterest, a balance with no tiers
+// is recovered straight from the flat AnnualRate.
+func inverseTieredInterest(product Product, interest Money) Money {
+	if len(product.Tiers) == 0 {
+		rate := monthlyRateFraction(product.AnnualRate)
+		if rate.IsZero() {
+			return decimal.Zero
+		}
+		return interest.Div(rate)
+	}
+
+	var balance Money
+	var prevCap Money
+	remaining := interest
+
+	for _, tier := range product.Tiers {
+		if remaining.IsZero() || remaining.IsNegative() {
+			break
+		}
+
+		rate := monthlyRateFraction(tier.AnnualRate)
+		isTopTier := tier.UpToBalance.IsZero()
+
+		var tierMaxInterest Money
+		if !isTopTier {
+			tierMaxInterest = tier.UpToBalance.Sub(prevCap).Mul(rate)
+		}
+
+		if isTopTier || remaining.LessThanOrEqual(tierMaxInterest) {
+			if rate.IsZero() {
+				break // a zero-rate tier can't have produced the remaining interest
+			}
+			balance = prevCap.Add(remaining.Div(rate))
+			break
+		}
+
+		remaining = remaining.Sub(tierMaxInterest)
+		balance = tier.UpToBalance
+		prevCap = tier.UpToBalance
+	}
+
+	return balance
+}
+
+// inverseEffectiveInterest is effectiveInterest's inverse: given interest
+// actually earned by a user who has been on product for monthsOnProduct
+// months, it recovers the balance that produced it, honoring the same
+// intro-rate window effectiveInterest would have applied going forward.
+func inverseEffectiveInterest(product Product, interest Money, monthsOnProduct int) Money {
+	if product.IntroMonths > 0 && monthsOnProduct <= product.IntroMonths && !product.IntroRate.IsZero() {
+		rate := monthlyRateFraction(product.IntroRate)
+		if rate.IsZero() {
+			return decimal.Zero
+		}
+		return interest.Div(rate)
+	}
+	return inverseTieredInterest(product, interest)
+}
+
+// monthsOnProduct returns how many consecutive months up to and including
+// monthlyData[index] the user has been on the product recorded there, so
+// an intro period can be measured from the month they actually switched.
+func monthsOnProduct(monthlyData []MonthlyData, index int) int {
+	if index < 0 || index >= len(monthlyData) {
+		return 0
+	}
+
+	name := monthlyData[index].CurrentProductName
+	months := 0
+	for i := index; i >= 0; i-- {
+		if monthlyData[i].CurrentProductName != name {
+			break
+		}
+		months++
+	}
+	return months
+}
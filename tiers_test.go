@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func decimalFromFloat(f float64) Money {
+	return decimal.NewFromFloat(f)
+}
+
+func TestTieredInterest(t *testing.T) {
+	tieredProduct := Product{
+		AnnualRate: decimalFromFloat(1), // unused fallback, tiers take over
+		Tiers: []RateTier{
+			{UpToBalance: decimalFromFloat(1000), AnnualRate: decimalFromFloat(2)},
+			{UpToBalance: decimalFromFloat(0), AnnualRate: decimalFromFloat(4)}, // top tier
+		},
+	}
+
+	cases := []struct {
+		name    string
+		product Product
+		balance Money
+		want    Money
+	}{
+		{
+			name:    "zero tiers falls back to flat AnnualRate",
+			product: Product{AnnualRate: decimalFromFloat(6)},
+			balance: decimalFromFloat(1200),
+			want:    decimalFromFloat(1200).Mul(monthlyRateFraction(decimalFromFloat(6))),
+		},
+		{
+			name:    "balance below tier boundary only earns the first tier's rate",
+			product: tieredProduct,
+			balance: decimalFromFloat(500),
+			want:    decimalFromFloat(500).Mul(monthlyRateFraction(decimalFromFloat(2))),
+		},
+		{
+			name:    "balance exactly at tier boundary stays in the first tier",
+			product: tieredProduct,
+			balance: decimalFromFloat(1000),
+			want:    decimalFromFloat(1000).Mul(monthlyRateFraction(decimalFromFloat(2))),
+		},
+		{
+			name:    "balance above tier boundary spills into the top tier",
+			product: tieredProduct,
+			balance: decimalFromFloat(1500),
+			want: decimalFromFloat(1000).Mul(monthlyRateFraction(decimalFromFloat(2))).
+				Add(decimalFromFloat(500).Mul(monthlyRateFraction(decimalFromFloat(4)))),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tieredInterest(tc.product, tc.balance)
+			if !got.Equal(tc.want) {
+				t.Errorf("tieredInterest() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveInterest(t *testing.T) {
+	product := Product{
+		AnnualRate:  decimalFromFloat(3),
+		IntroRate:   decimalFromFloat(1),
+		IntroMonths: 3,
+	}
+	balance := decimalFromFloat(1000)
+
+	cases := []struct {
+		name            string
+		monthsOnProduct int
+		want            Money
+	}{
+		{
+			name:            "intro rate applies on the first month",
+			monthsOnProduct: 1,
+			want:            balance.Mul(monthlyRateFraction(product.IntroRate)),
+		},
+		{
+			name:            "intro rate still applies exactly at IntroMonths",
+			monthsOnProduct: 3,
+			want:            balance.Mul(monthlyRateFraction(product.IntroRate)),
+		},
+		{
+			name:            "falls back to the standard rate the month after IntroMonths expires",
+			monthsOnProduct: 4,
+			want:            balance.Mul(monthlyRateFraction(product.AnnualRate)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := effectiveInterest(product, balance, tc.monthsOnProduct)
+			if !got.Equal(tc.want) {
+				t.Errorf("effectiveInterest() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}